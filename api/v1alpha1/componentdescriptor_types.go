@@ -0,0 +1,129 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fluxcd/pkg/apis/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ocmmetav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	compdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/ocm.software/v3alpha1"
+)
+
+// ReferencedByAnnotation holds a comma-separated, sorted set of the UIDs of
+// every ComponentVersion that currently references a ComponentDescriptor.
+// Because a descriptor is content-addressed, several ComponentVersion trees
+// may legitimately share one: the descriptor is only garbage-collected once
+// this set becomes empty.
+const ReferencedByAnnotation = "ocm.software/referenced-by"
+
+// ComponentDescriptorSpec defines the desired state of ComponentDescriptor
+type ComponentDescriptorSpec struct {
+	// +required
+	ComponentVersionSpec compdesc.ComponentSpec `json:"componentVersionSpec,omitempty"`
+
+	// +required
+	Version string `json:"version"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ComponentDescriptor is the Schema for the componentdescriptors API
+type ComponentDescriptor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComponentDescriptorSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentDescriptorList contains a list of ComponentDescriptor
+type ComponentDescriptorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentDescriptor `json:"items"`
+}
+
+// Reference defines a reference to a ComponentDescriptor resource, plus any
+// embedded references that were discovered while expanding the component's
+// dependency tree.
+type Reference struct {
+	// +required
+	Name string `json:"name"`
+
+	// +required
+	Version string `json:"version"`
+
+	// +optional
+	ExtraIdentity ocmmetav1.Identity `json:"extraIdentity,omitempty"`
+
+	// +required
+	ComponentDescriptorRef meta.NamespacedObjectReference `json:"componentDescriptorRef"`
+
+	// +optional
+	References []Reference `json:"references,omitempty"`
+
+	// Digest is the content digest of the referenced ComponentDescriptor,
+	// i.e. a SHA-256 over its canonicalized spec. It's what the descriptor's
+	// name is derived from.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// AddReferencedBy records uid as referencing cd.
+func AddReferencedBy(cd *ComponentDescriptor, uid types.UID) {
+	set := referencedBySet(cd)
+	set[string(uid)] = struct{}{}
+	setReferencedBySet(cd, set)
+}
+
+// RemoveReferencedBy removes uid from cd's referenced-by set and reports
+// whether the set is now empty, i.e. whether cd is safe to delete.
+func RemoveReferencedBy(cd *ComponentDescriptor, uid types.UID) bool {
+	set := referencedBySet(cd)
+	delete(set, string(uid))
+	setReferencedBySet(cd, set)
+	return len(set) == 0
+}
+
+func referencedBySet(cd *ComponentDescriptor) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, id := range strings.Split(cd.GetAnnotations()[ReferencedByAnnotation], ",") {
+		if id != "" {
+			set[id] = struct{}{}
+		}
+	}
+	return set
+}
+
+func setReferencedBySet(cd *ComponentDescriptor, set map[string]struct{}) {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	annotations := cd.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(ids) == 0 {
+		delete(annotations, ReferencedByAnnotation)
+	} else {
+		annotations[ReferencedByAnnotation] = strings.Join(ids, ",")
+	}
+	cd.SetAnnotations(annotations)
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentDescriptor{}, &ComponentDescriptorList{})
+}
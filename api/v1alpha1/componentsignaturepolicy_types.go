@@ -0,0 +1,154 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SignerType identifies which verification backend a Signer's key material
+// is consumed by.
+type SignerType string
+
+const (
+	SignerTypePGP           SignerType = "pgp"
+	SignerTypeCosign        SignerType = "cosign"
+	SignerTypeCosignKeyless SignerType = "cosign-keyless"
+	SignerTypeNotation      SignerType = "notation"
+	SignerTypeX509          SignerType = "x509"
+)
+
+// Signer describes a single piece of key material a policy trusts.
+type Signer struct {
+	// +required
+	Name string `json:"name"`
+
+	// +required
+	// +kubebuilder:validation:Enum=pgp;cosign;cosign-keyless;notation;x509
+	Type SignerType `json:"type"`
+
+	// SecretRef points at the key material for pgp, cosign, notation and
+	// x509 signers: an armored PGP public key, a cosign public key, a
+	// notation trust store bundle, or a PEM-encoded certificate (chain),
+	// respectively.
+	// +optional
+	SecretRef SecretRef `json:"secretRef,omitempty"`
+
+	// OIDCIssuer and OIDCSubject identify the expected keyless-cosign
+	// signing identity of a cosign-keyless signer. No verifier backend
+	// reads them: the cosign backend returns an error for every
+	// cosign-keyless signer.
+	// +optional
+	OIDCIssuer string `json:"oidcIssuer,omitempty"`
+
+	// +optional
+	OIDCSubject string `json:"oidcSubject,omitempty"`
+}
+
+// ComponentMatch selects which components a policy applies to.
+type ComponentMatch struct {
+	// Component is the OCM component name the policy applies to. Supports a
+	// trailing "*" as a prefix wildcard.
+	// +required
+	Component string `json:"component"`
+}
+
+// Matches reports whether componentName satisfies m.
+func (m ComponentMatch) Matches(componentName string) bool {
+	if strings.HasSuffix(m.Component, "*") {
+		return strings.HasPrefix(componentName, strings.TrimSuffix(m.Component, "*"))
+	}
+	return m.Component == componentName
+}
+
+// ComponentSignaturePolicySpec defines the desired state of
+// ComponentSignaturePolicy.
+type ComponentSignaturePolicySpec struct {
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Signers []Signer `json:"signers"`
+
+	// SignatureAlgorithms restricts which signature algorithms (the
+	// algorithm recorded on a descriptor's signature entry) are accepted;
+	// the digest's hash algorithm is not checked against it. Empty means
+	// any algorithm a signer's backend supports.
+	// +optional
+	SignatureAlgorithms []string `json:"signatureAlgorithms,omitempty"`
+
+	// MatchComponents, if set, restricts this policy to the listed
+	// components. A ComponentVersion whose Spec.Component doesn't match any
+	// entry is rejected even when it references this policy explicitly via
+	// Signature.PolicyRef.
+	// +optional
+	MatchComponents []ComponentMatch `json:"matchComponents,omitempty"`
+}
+
+// AllowsAlgorithm reports whether algorithm is permitted by
+// s.SignatureAlgorithms. An empty SignatureAlgorithms allows every
+// algorithm.
+func (s ComponentSignaturePolicySpec) AllowsAlgorithm(algorithm string) bool {
+	if len(s.SignatureAlgorithms) == 0 {
+		return true
+	}
+	for _, allowed := range s.SignatureAlgorithms {
+		if allowed == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesComponent reports whether componentName is covered by s. An empty
+// MatchComponents covers every component.
+func (s ComponentSignaturePolicySpec) MatchesComponent(componentName string) bool {
+	if len(s.MatchComponents) == 0 {
+		return true
+	}
+	for _, match := range s.MatchComponents {
+		if match.Matches(componentName) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComponentSignaturePolicyStatus defines the observed state of
+// ComponentSignaturePolicy.
+type ComponentSignaturePolicyStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// ComponentSignaturePolicy is the Schema for the componentsignaturepolicies API
+type ComponentSignaturePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentSignaturePolicySpec   `json:"spec,omitempty"`
+	Status ComponentSignaturePolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentSignaturePolicyList contains a list of ComponentSignaturePolicy
+type ComponentSignaturePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentSignaturePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentSignaturePolicy{}, &ComponentSignaturePolicyList{})
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentMatchMatches(t *testing.T) {
+	assert.True(t, ComponentMatch{Component: "github.com/skarlso/root"}.Matches("github.com/skarlso/root"))
+	assert.False(t, ComponentMatch{Component: "github.com/skarlso/root"}.Matches("github.com/skarlso/other"))
+	assert.True(t, ComponentMatch{Component: "github.com/skarlso/*"}.Matches("github.com/skarlso/root"))
+	assert.False(t, ComponentMatch{Component: "github.com/skarlso/*"}.Matches("github.com/other/root"))
+}
+
+func TestComponentSignaturePolicySpecMatchesComponent(t *testing.T) {
+	empty := ComponentSignaturePolicySpec{}
+	assert.True(t, empty.MatchesComponent("github.com/skarlso/root"), "an empty MatchComponents covers every component")
+
+	spec := ComponentSignaturePolicySpec{
+		MatchComponents: []ComponentMatch{{Component: "github.com/skarlso/*"}},
+	}
+	assert.True(t, spec.MatchesComponent("github.com/skarlso/root"))
+	assert.False(t, spec.MatchesComponent("github.com/other/root"))
+}
+
+func TestComponentSignaturePolicySpecAllowsAlgorithm(t *testing.T) {
+	empty := ComponentSignaturePolicySpec{}
+	assert.True(t, empty.AllowsAlgorithm("RSASSA-PKCS1-V1_5"), "an empty SignatureAlgorithms allows every algorithm")
+
+	spec := ComponentSignaturePolicySpec{
+		SignatureAlgorithms: []string{"RSASSA-PKCS1-V1_5"},
+	}
+	assert.True(t, spec.AllowsAlgorithm("RSASSA-PKCS1-V1_5"))
+	assert.False(t, spec.AllowsAlgorithm("ECDSA"))
+}
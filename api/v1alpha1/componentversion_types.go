@@ -0,0 +1,148 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentVersionFinalizer is set on every ComponentVersion so the
+// reconciler can remove this object's claim on its (possibly shared)
+// ComponentDescriptor tree before the object is actually deleted.
+const ComponentVersionFinalizer = "finalizers.delivery.ocm.software"
+
+// SecretRef is a reference to a Secret containing credentials.
+type SecretRef struct {
+	// +required
+	Name string `json:"name"`
+}
+
+// Repository defines the OCM repository a ComponentVersion is fetched from.
+type Repository struct {
+	// +required
+	URL string `json:"url"`
+
+	// +optional
+	SecretRef SecretRef `json:"secretRef,omitempty"`
+}
+
+// Signature references the key material required to verify a single
+// signature on a component.
+type Signature struct {
+	// +required
+	Name string `json:"name"`
+
+	// +optional
+	PublicKey SecretRef `json:"publicKey,omitempty"`
+
+	// PolicyRef points at the ComponentSignaturePolicy (in the same
+	// namespace) that governs how this signature is verified: which
+	// backend, which trusted signers, which algorithms. Verification
+	// requires it: an entry without a PolicyRef fails verification, and
+	// PublicKey is not read by the verifier.
+	// +optional
+	PolicyRef PolicyReference `json:"policyRef,omitempty"`
+}
+
+// PolicyReference is a reference to a ComponentSignaturePolicy in the same
+// namespace as the referencing ComponentVersion.
+type PolicyReference struct {
+	// +required
+	Name string `json:"name"`
+}
+
+// VerifiedSignature records that a named signer's key satisfied a signature
+// on the component, so users can audit which key actually verified it.
+type VerifiedSignature struct {
+	// Name is the Signer name from the ComponentSignaturePolicy that matched.
+	Name string `json:"name"`
+
+	// KeyFingerprint identifies the exact key material that verified the
+	// signature, e.g. a PGP fingerprint or cosign key digest.
+	KeyFingerprint string `json:"keyFingerprint,omitempty"`
+}
+
+// ReferencesConfig controls whether and how a ComponentVersion's embedded
+// references are expanded into a dependency tree.
+type ReferencesConfig struct {
+	// +optional
+	Expand bool `json:"expand,omitempty"`
+}
+
+// ComponentVersionSpec defines the desired state of ComponentVersion
+type ComponentVersionSpec struct {
+	// +required
+	Interval metav1.Duration `json:"interval"`
+
+	// +required
+	Component string `json:"component"`
+
+	// +required
+	Version string `json:"version"`
+
+	// +required
+	Repository Repository `json:"repository"`
+
+	// +optional
+	Verify []Signature `json:"verify,omitempty"`
+
+	// +optional
+	References ReferencesConfig `json:"references,omitempty"`
+}
+
+// ComponentVersionStatus defines the observed state of ComponentVersion
+type ComponentVersionStatus struct {
+	// ObservedGeneration is the last reconciled generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	ComponentDescriptor Reference `json:"componentDescriptor,omitempty"`
+
+	// VerifiedSignatures records, per Spec.Verify entry, which signer and
+	// key fingerprint satisfied the policy.
+	// +optional
+	VerifiedSignatures []VerifiedSignature `json:"verifiedSignatures,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// ComponentVersion is the Schema for the componentversions API
+type ComponentVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentVersionSpec   `json:"spec,omitempty"`
+	Status ComponentVersionStatus `json:"status,omitempty"`
+}
+
+// GetRequeueAfter returns the duration after which the ComponentVersion must
+// be reconciled again.
+func (in ComponentVersion) GetRequeueAfter() time.Duration {
+	return in.Spec.Interval.Duration
+}
+
+//+kubebuilder:object:root=true
+
+// ComponentVersionList contains a list of ComponentVersion
+type ComponentVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentVersion `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentVersion{}, &ComponentVersionList{})
+}
@@ -0,0 +1,39 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+const (
+	// SourceVerifiedCondition indicates whether the signature(s) configured on
+	// a ComponentVersion have been successfully verified.
+	SourceVerifiedCondition string = "SourceVerified"
+)
+
+const (
+	// VerificationSucceededReason is used when a component's signature(s)
+	// were verified successfully.
+	VerificationSucceededReason string = "VerificationSucceeded"
+
+	// VerificationFailedReason is used when a component's signature(s) could
+	// not be verified, e.g. because the digest doesn't match.
+	VerificationFailedReason string = "VerificationFailed"
+
+	// ComponentVersionFetchFailedReason is used when the OCM component
+	// version or one of its referenced components could not be fetched,
+	// e.g. because the registry is unreachable.
+	ComponentVersionFetchFailedReason string = "ComponentVersionFetchFailed"
+
+	// ReconcileFailedReason is used when patching the resource or one of its
+	// dependent objects failed.
+	ReconcileFailedReason string = "ReconcileFailed"
+
+	// ReconcileSucceededReason is used when a reconciliation finished
+	// successfully.
+	ReconcileSucceededReason string = "ReconcileSucceeded"
+
+	// ProgressingReason is used when a reconciliation has started and is
+	// still in progress.
+	ProgressingReason string = "Progressing"
+)
@@ -54,6 +54,13 @@ type ResourceRef struct {
 }
 
 // LocalizationStatus defines the observed state of Localization
+//
+// NOTE: this tree has no LocalizationReconciler yet (and SnapshotTemplateSpec
+// above isn't even defined here), so Ready/Reconciling/Stalled condition
+// management, ObservedGeneration patching and terminal-vs-transient handling
+// like ComponentVersionReconciler's (see componentversion_controller.go)
+// can't be mirrored onto Localization until that controller exists. The
+// fields below are ready for it in the meantime.
 type LocalizationStatus struct {
 	// ObservedGeneration is the last reconciled generation.
 	// +optional
@@ -70,6 +77,9 @@ type LocalizationStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // Localization is the Schema for the localizations API
 type Localization struct {
@@ -6,14 +6,24 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	_ "github.com/open-component-model/ocm/pkg/contexts/datacontext/config"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
@@ -21,6 +31,7 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 
 	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	ocmclient "github.com/open-component-model/ocm-controller/pkg/ocm"
 )
 
 func TestComponentVersionReconcile(t *testing.T) {
@@ -116,21 +127,356 @@ func TestComponentVersionReconcile(t *testing.T) {
 	assert.Equal(t, "test-ref-1", obj.Status.ComponentDescriptor.References[0].Name)
 }
 
+func TestComponentVersionReconcileStalledOnVerificationFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := v1alpha1.AddToScheme(scheme)
+	assert.NoError(t, err)
+	err = corev1.AddToScheme(scheme)
+	assert.NoError(t, err)
+
+	var (
+		componentName = "test-name"
+		namespace     = "default"
+	)
+	obj := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       componentName,
+			Namespace:  namespace,
+			Finalizers: []string{v1alpha1.ComponentVersionFinalizer},
+		},
+		Spec: v1alpha1.ComponentVersionSpec{
+			Interval:  metav1.Duration{Duration: 10 * time.Minute},
+			Component: "github.com/skarlso/root",
+			Version:   "v0.0.1",
+			Verify:    []v1alpha1.Signature{},
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(obj).WithScheme(scheme).Build()
+	cvr := ComponentVersionReconciler{
+		Scheme:   scheme,
+		Client:   client,
+		Recorder: record.NewFakeRecorder(32),
+		OCMClient: &mockFetcher{
+			verified: false,
+			t:        t,
+		},
+	}
+
+	result, err := cvr.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: componentName, Namespace: namespace},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	assert.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: componentName, Namespace: namespace}, obj))
+	stalled := apimeta.FindStatusCondition(obj.Status.Conditions, fluxmeta.StalledCondition)
+	assert.NotNil(t, stalled)
+	assert.Equal(t, metav1.ConditionTrue, stalled.Status)
+	ready := apimeta.FindStatusCondition(obj.Status.Conditions, fluxmeta.ReadyCondition)
+	assert.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+}
+
+func TestComponentVersionReconcileRequeuesOnTransientVerificationError(t *testing.T) {
+	scheme := runtime.NewScheme()
+	err := v1alpha1.AddToScheme(scheme)
+	assert.NoError(t, err)
+	err = corev1.AddToScheme(scheme)
+	assert.NoError(t, err)
+
+	var (
+		componentName = "test-name"
+		namespace     = "default"
+	)
+	obj := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       componentName,
+			Namespace:  namespace,
+			Finalizers: []string{v1alpha1.ComponentVersionFinalizer},
+		},
+		Spec: v1alpha1.ComponentVersionSpec{
+			Interval:  metav1.Duration{Duration: 10 * time.Minute},
+			Component: "github.com/skarlso/root",
+			Version:   "v0.0.1",
+			Verify:    []v1alpha1.Signature{},
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(obj).WithScheme(scheme).Build()
+	cvr := ComponentVersionReconciler{
+		Scheme:   scheme,
+		Client:   client,
+		Recorder: record.NewFakeRecorder(32),
+		OCMClient: &mockFetcher{
+			verifyErr: fmt.Errorf("failed to get component version to verify: registry unreachable"),
+			t:         t,
+		},
+	}
+
+	result, err := cvr.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: componentName, Namespace: namespace},
+	})
+	assert.Error(t, err)
+	assert.False(t, IsTerminalError(err))
+	assert.Equal(t, obj.GetRequeueAfter(), result.RequeueAfter)
+
+	assert.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: componentName, Namespace: namespace}, obj))
+	stalled := apimeta.FindStatusCondition(obj.Status.Conditions, fluxmeta.StalledCondition)
+	assert.Nil(t, stalled)
+	reconciling := apimeta.FindStatusCondition(obj.Status.Conditions, fluxmeta.ReconcilingCondition)
+	assert.NotNil(t, reconciling)
+	assert.Equal(t, metav1.ConditionTrue, reconciling.Status)
+}
+
+func TestComponentVersionReconcilerComponentSelectorPredicate(t *testing.T) {
+	selector, err := labels.Parse("ocm.software/shard=a,!ocm.software/ignore")
+	assert.NoError(t, err)
+
+	r := &ComponentVersionReconciler{ComponentSelector: selector}
+	predicate := r.componentSelectorPredicate()
+
+	matching := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"ocm.software/shard": "a"}},
+	}
+	assert.True(t, predicate.Create(event.CreateEvent{Object: matching}))
+
+	wrongShard := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"ocm.software/shard": "b"}},
+	}
+	assert.False(t, predicate.Create(event.CreateEvent{Object: wrongShard}))
+
+	ignored := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			"ocm.software/shard":  "a",
+			"ocm.software/ignore": "true",
+		}},
+	}
+	assert.False(t, predicate.Create(event.CreateEvent{Object: ignored}))
+}
+
+func TestComponentVersionReconcileSharesDescriptorAcrossParents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+
+	namespace := "default"
+	embedded := &mockComponent{
+		descriptor: &ocmdesc.ComponentDescriptor{
+			ComponentSpec: ocmdesc.ComponentSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Name:    "github.com/skarlso/embedded",
+					Version: "v0.0.1",
+				},
+			},
+		},
+	}
+
+	newRoot := func(name string, uid types.UID) *v1alpha1.ComponentVersion {
+		return &v1alpha1.ComponentVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: uid},
+			Spec: v1alpha1.ComponentVersionSpec{
+				Interval:  metav1.Duration{Duration: 10 * time.Minute},
+				Component: "github.com/skarlso/root",
+				Version:   "v0.0.1",
+				Verify:    []v1alpha1.Signature{},
+				References: v1alpha1.ReferencesConfig{
+					Expand: true,
+				},
+			},
+		}
+	}
+
+	rootA := newRoot("root-a", "uid-a")
+	rootB := newRoot("root-b", "uid-b")
+
+	rootDescriptor := &ocmdesc.ComponentDescriptor{
+		ComponentSpec: ocmdesc.ComponentSpec{
+			ObjectMeta: v1.ObjectMeta{Name: "github.com/skarlso/root", Version: "v0.0.1"},
+			References: ocmdesc.References{
+				{
+					ElementMeta:   ocmdesc.ElementMeta{Name: "test-ref-1", Version: "v0.0.1"},
+					ComponentName: "github.com/skarlso/embedded",
+				},
+			},
+		},
+	}
+	root := &mockComponent{t: t, descriptor: rootDescriptor}
+
+	client := fake.NewClientBuilder().WithObjects(rootA, rootB).WithScheme(scheme).Build()
+	cvr := ComponentVersionReconciler{
+		Scheme: scheme,
+		Client: client,
+		OCMClient: &mockFetcher{
+			verified: true,
+			t:        t,
+			cv: map[string]ocm.ComponentVersionAccess{
+				"github.com/skarlso/embedded": embedded,
+				"github.com/skarlso/root":     root,
+			},
+		},
+	}
+
+	_, err := cvr.reconcile(context.Background(), rootA)
+	assert.NoError(t, err)
+	_, err = cvr.reconcile(context.Background(), rootB)
+	assert.NoError(t, err)
+
+	embeddedRef := rootA.Status.ComponentDescriptor.References[0]
+	assert.Equal(t, embeddedRef.ComponentDescriptorRef.Name, rootB.Status.ComponentDescriptor.References[0].ComponentDescriptorRef.Name,
+		"both roots should share the same content-addressed descriptor")
+	assert.NotEmpty(t, embeddedRef.Digest)
+
+	descriptor := &v1alpha1.ComponentDescriptor{}
+	assert.NoError(t, client.Get(context.Background(), types.NamespacedName{
+		Namespace: namespace, Name: embeddedRef.ComponentDescriptorRef.Name,
+	}, descriptor))
+	assert.Len(t, descriptor.GetOwnerReferences(), 2)
+	assert.Equal(t, "uid-a,uid-b", descriptor.GetAnnotations()[v1alpha1.ReferencedByAnnotation])
+}
+
+func TestComponentVersionReconcileDeleteGarbageCollectsOrphanedDescriptor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+
+	namespace := "default"
+	descriptorName := "github-com-skarlso-embedded-v0-0-1-abc123"
+	descriptor := &v1alpha1.ComponentDescriptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        descriptorName,
+			Annotations: map[string]string{v1alpha1.ReferencedByAnnotation: "uid-a"},
+		},
+	}
+	component := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "root-a",
+			Namespace:         namespace,
+			UID:               "uid-a",
+			Finalizers:        []string{v1alpha1.ComponentVersionFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Status: v1alpha1.ComponentVersionStatus{
+			ComponentDescriptor: v1alpha1.Reference{
+				Name: "github.com/skarlso/embedded",
+				ComponentDescriptorRef: fluxmeta.NamespacedObjectReference{
+					Name:      descriptorName,
+					Namespace: namespace,
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(descriptor, component).WithScheme(scheme).Build()
+	cvr := ComponentVersionReconciler{Scheme: scheme, Client: client}
+
+	_, err := cvr.reconcileDelete(context.Background(), component)
+	assert.NoError(t, err)
+
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: descriptorName}, &v1alpha1.ComponentDescriptor{})
+	assert.True(t, apierrors.IsNotFound(err), "descriptor should be garbage collected once its referenced-by set is empty")
+}
+
+func TestGCDroppedReferencesReleasesDescriptorDroppedAcrossGenerations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+
+	namespace := "default"
+	rootDescriptorName := "github-com-skarlso-root-v0-0-1-root123"
+	droppedDescriptorName := "github-com-skarlso-dropped-v0-0-1-abc123"
+	rootDescriptor := &v1alpha1.ComponentDescriptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        rootDescriptorName,
+			Annotations: map[string]string{v1alpha1.ReferencedByAnnotation: "uid-a"},
+		},
+	}
+	droppedDescriptor := &v1alpha1.ComponentDescriptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        droppedDescriptorName,
+			Annotations: map[string]string{v1alpha1.ReferencedByAnnotation: "uid-a"},
+		},
+	}
+	component := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "root-a",
+			Namespace: namespace,
+			UID:       "uid-a",
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(rootDescriptor, droppedDescriptor, component).WithScheme(scheme).Build()
+	cvr := ComponentVersionReconciler{Scheme: scheme, Client: client}
+
+	rootRef := func(refs ...v1alpha1.Reference) v1alpha1.Reference {
+		return v1alpha1.Reference{
+			Name: "github.com/skarlso/root",
+			ComponentDescriptorRef: fluxmeta.NamespacedObjectReference{
+				Name:      rootDescriptorName,
+				Namespace: namespace,
+			},
+			References: refs,
+		}
+	}
+	droppedRef := v1alpha1.Reference{
+		Name: "github.com/skarlso/dropped",
+		ComponentDescriptorRef: fluxmeta.NamespacedObjectReference{
+			Name:      droppedDescriptorName,
+			Namespace: namespace,
+		},
+	}
+
+	previous := rootRef(droppedRef)
+	current := rootRef()
+
+	err := cvr.gcDroppedReferences(context.Background(), component, previous, current)
+	assert.NoError(t, err)
+
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: droppedDescriptorName}, &v1alpha1.ComponentDescriptor{})
+	assert.True(t, apierrors.IsNotFound(err), "descriptor dropped from the reference tree should be garbage collected")
+
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: rootDescriptorName}, &v1alpha1.ComponentDescriptor{})
+	assert.NoError(t, err, "descriptor still present in the current tree must not be touched")
+}
+
 type mockFetcher struct {
 	getComponentErr error
 	verifyErr       error
 	cv              map[string]ocm.ComponentVersionAccess
 	t               *testing.T
 	verified        bool
+
+	// delay, if set, is slept before returning from GetComponentVersion,
+	// widening the window for concurrent callers to race each other - used
+	// to make diamond-deduplication tests actually exercise the race
+	// instead of relying on the call returning synchronously.
+	delay time.Duration
+
+	mu    sync.Mutex
+	calls map[string]int
 }
 
 func (m *mockFetcher) GetComponentVersion(ctx context.Context, obj *v1alpha1.ComponentVersion, name, version string) (ocm.ComponentVersionAccess, error) {
 	m.t.Logf("called GetComponentVersion with name %s and version %s", name, version)
+	if m.delay > 0 {
+		time.Sleep(m.delay)
+	}
+	m.mu.Lock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[name]++
+	m.mu.Unlock()
 	return m.cv[name], m.getComponentErr
 }
 
-func (m *mockFetcher) VerifyComponent(ctx context.Context, obj *v1alpha1.ComponentVersion) (bool, error) {
-	return m.verified, m.verifyErr
+func (m *mockFetcher) VerifyComponent(ctx context.Context, obj *v1alpha1.ComponentVersion) (ocmclient.VerificationResult, error) {
+	if m.verifyErr != nil {
+		return ocmclient.VerificationResult{}, m.verifyErr
+	}
+	if !m.verified {
+		return ocmclient.VerificationResult{}, fmt.Errorf("signature verification failed")
+	}
+	return ocmclient.VerificationResult{Signatures: []v1alpha1.VerifiedSignature{}}, nil
 }
 
 type mockComponent struct {
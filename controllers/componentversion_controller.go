@@ -7,15 +7,20 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/fluxcd/pkg/apis/meta"
-	hash "github.com/mitchellh/hashstructure"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/klog/v2"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -25,34 +30,75 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	ocmclient "github.com/open-component-model/ocm-controller/pkg/ocm"
-	ocmdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
-	v1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
 	compdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/ocm.software/v3alpha1"
 
 	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/metrics"
 )
 
+// componentDescriptorNameDigestLength is the number of hex characters of the
+// SHA-256 content digest used in a ComponentDescriptor's name.
+const componentDescriptorNameDigestLength = 12
+
 // ComponentVersionReconciler reconciles a ComponentVersion object
 type ComponentVersionReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 
 	OCMClient ocmclient.FetchVerifier
+
+	// ComponentSelector, when set, restricts reconciliation to
+	// ComponentVersion objects whose labels match it. It supports
+	// negated keys (e.g. "!ocm.software/ignore") in addition to the
+	// usual "key=value" requirements, letting several ocm-controller
+	// instances shard a cluster without stepping on each other. A nil
+	// selector matches everything.
+	ComponentSelector labels.Selector
+
+	// MaxReferenceDepth bounds how deep a component's reference tree may be
+	// expanded before reconciliation is stalled. Zero uses
+	// defaultMaxReferenceDepth.
+	MaxReferenceDepth int
+
+	// MaxReferenceCount bounds how many unique components a reference tree
+	// may expand to before reconciliation is stalled. Zero uses
+	// defaultMaxReferenceCount.
+	MaxReferenceCount int
+
+	// Concurrency is the number of sibling references fetched in parallel
+	// while expanding a reference tree. Zero uses defaultReferenceConcurrency.
+	Concurrency int
 }
 
 //+kubebuilder:rbac:groups=delivery.ocm.software,resources=componentversions;componentdescriptors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=delivery.ocm.software,resources=componentversions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=delivery.ocm.software,resources=componentversions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=delivery.ocm.software,resources=componentsignaturepolicies,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups="",resources=secrets;serviceaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ComponentVersionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("ocm-component-version-controller")
+	predicates := []predicate.Predicate{predicate.GenerationChangedPredicate{}}
+	if r.ComponentSelector != nil {
+		predicates = append(predicates, r.componentSelectorPredicate())
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ComponentVersion{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&v1alpha1.ComponentVersion{}, builder.WithPredicates(predicates...)).
 		Complete(r)
 }
 
+// componentSelectorPredicate only enqueues ComponentVersion objects whose
+// labels match r.ComponentSelector.
+func (r *ComponentVersionReconciler) componentSelectorPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.ComponentSelector.Matches(labels.Set(obj.GetLabels()))
+	})
+}
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *ComponentVersionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -68,49 +114,309 @@ func (r *ComponentVersionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, fmt.Errorf("failed to get component object: %w", err)
 	}
 
-	log.V(4).Info("found component", "component", component)
+	if !component.GetDeletionTimestamp().IsZero() {
+		return r.reconcileDelete(ctx, component)
+	}
+
+	if !controllerutil.ContainsFinalizer(component, v1alpha1.ComponentVersionFinalizer) {
+		controllerutil.AddFinalizer(component, v1alpha1.ComponentVersionFinalizer)
+		if err := r.Client.Update(ctx, component); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
 
-	log.Info("running verification of component")
-	ok, err := r.OCMClient.VerifyComponent(ctx, component)
+	patchHelper, err := patch.NewHelper(component, r.Client)
 	if err != nil {
-		return ctrl.Result{
-			RequeueAfter: component.GetRequeueAfter(),
-		}, fmt.Errorf("failed to verify component: %w", err)
+		return ctrl.Result{}, fmt.Errorf("failed to create patch helper: %w", err)
+	}
+
+	component.Status.ObservedGeneration = component.Generation
+	apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    fluxmeta.ReconcilingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.ProgressingReason,
+		Message: "reconciliation in progress",
+	})
+
+	start := time.Now()
+	result, retErr := r.reconcile(ctx, component)
+	metrics.ObserveReconcile(reconcileResult(retErr), start)
+
+	r.markStatus(component, result, retErr)
+
+	if err := patchHelper.Patch(ctx, component); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch resource: %w", err)
+	}
+
+	// A terminal error is recorded as Stalled=True above; returning it would
+	// make controller-runtime requeue the object with backoff.
+	if IsTerminalError(retErr) {
+		return ctrl.Result{}, nil
+	}
+
+	return result, retErr
+}
+
+// reconcileDelete releases this ComponentVersion's claim on every
+// ComponentDescriptor in its expanded reference tree. Because descriptors are
+// content-addressed and may be shared by several ComponentVersion roots, a
+// descriptor is only deleted once its referenced-by set becomes empty.
+func (r *ComponentVersionReconciler) reconcileDelete(ctx context.Context, component *v1alpha1.ComponentVersion) (ctrl.Result, error) {
+	log := log.FromContext(ctx).WithName("ocm-component-version-reconcile")
+
+	for _, ref := range flattenReferences(component.Status.ComponentDescriptor) {
+		descriptor := &v1alpha1.ComponentDescriptor{}
+		key := client.ObjectKey{Namespace: ref.ComponentDescriptorRef.Namespace, Name: ref.ComponentDescriptorRef.Name}
+		if err := r.Client.Get(ctx, key, descriptor); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to get component descriptor %s: %w", key, err)
+		}
+
+		if empty := v1alpha1.RemoveReferencedBy(descriptor, component.GetUID()); empty {
+			if err := r.Client.Delete(ctx, descriptor); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to delete component descriptor %s: %w", key, err)
+			}
+			log.V(4).Info("deleted orphaned component descriptor", "descriptor", key)
+			continue
+		}
+
+		if err := r.Client.Update(ctx, descriptor); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update component descriptor %s: %w", key, err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(component, v1alpha1.ComponentVersionFinalizer)
+	if err := r.Client.Update(ctx, component); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// gcDroppedReferences releases component's claim on every ComponentDescriptor
+// that appears in previous's tree but not in current's, e.g. because an
+// upstream reference was removed between reconciles. Without this, a
+// descriptor dropped from the middle of a shrinking reference tree would
+// never have its referenced-by entry cleared, since reconcileDelete only
+// walks the tree recorded at the time of actual deletion. As in
+// reconcileDelete, a descriptor is only deleted once its referenced-by set
+// becomes empty.
+func (r *ComponentVersionReconciler) gcDroppedReferences(ctx context.Context, component *v1alpha1.ComponentVersion, previous, current v1alpha1.Reference) error {
+	log := log.FromContext(ctx).WithName("ocm-component-version-reconcile")
+
+	kept := make(map[client.ObjectKey]struct{})
+	for _, ref := range flattenReferences(current) {
+		kept[client.ObjectKey{Namespace: ref.ComponentDescriptorRef.Namespace, Name: ref.ComponentDescriptorRef.Name}] = struct{}{}
+	}
+
+	for _, ref := range flattenReferences(previous) {
+		key := client.ObjectKey{Namespace: ref.ComponentDescriptorRef.Namespace, Name: ref.ComponentDescriptorRef.Name}
+		if _, ok := kept[key]; ok {
+			continue
+		}
+
+		descriptor := &v1alpha1.ComponentDescriptor{}
+		if err := r.Client.Get(ctx, key, descriptor); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get dropped component descriptor %s: %w", key, err)
+		}
+
+		if empty := v1alpha1.RemoveReferencedBy(descriptor, component.GetUID()); empty {
+			if err := r.Client.Delete(ctx, descriptor); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete dropped component descriptor %s: %w", key, err)
+			}
+			log.V(4).Info("deleted component descriptor dropped from reference tree", "descriptor", key)
+			continue
+		}
+
+		if err := r.Client.Update(ctx, descriptor); err != nil {
+			return fmt.Errorf("failed to update dropped component descriptor %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// flattenReferences walks root and its nested References, returning every
+// node in the tree.
+func flattenReferences(root v1alpha1.Reference) []v1alpha1.Reference {
+	if root.ComponentDescriptorRef.Name == "" {
+		return nil
+	}
+	result := []v1alpha1.Reference{root}
+	for _, child := range root.References {
+		result = append(result, flattenReferences(child)...)
+	}
+	return result
+}
+
+// countReferences returns the total number of components in refs and all of
+// their nested references.
+func countReferences(refs []v1alpha1.Reference) int {
+	count := 0
+	for _, ref := range refs {
+		count += len(flattenReferences(ref))
+	}
+	return count
+}
+
+// markStatus translates the outcome of a reconcile into the ComponentVersion's
+// condition set and emits a matching event. Transient errors (registry
+// unreachable, patch conflicts, ...) leave Reconciling=True so the object is
+// requeued; terminal errors (bad signature, malformed descriptor, missing
+// reference, ...) set Stalled=True instead, so a permanently broken
+// ComponentVersion doesn't hot-loop.
+func (r *ComponentVersionReconciler) markStatus(component *v1alpha1.ComponentVersion, result ctrl.Result, err error) {
+	previousReady := apimeta.FindStatusCondition(component.Status.Conditions, fluxmeta.ReadyCondition)
+
+	apimeta.RemoveStatusCondition(&component.Status.Conditions, fluxmeta.ReconcilingCondition)
+
+	if err == nil {
+		apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+			Type:    fluxmeta.ReadyCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.ReconcileSucceededReason,
+			Message: "reconciliation succeeded",
+		})
+		apimeta.RemoveStatusCondition(&component.Status.Conditions, fluxmeta.StalledCondition)
+		if previousReady == nil || previousReady.Status != metav1.ConditionTrue {
+			r.Recorder.Event(component, "Normal", v1alpha1.ReconcileSucceededReason, "reconciliation succeeded")
+		}
+		return
 	}
 
-	if !ok {
-		return ctrl.Result{
-			RequeueAfter: component.GetRequeueAfter(),
-		}, fmt.Errorf("attempted to verify component, but the digest didn't match")
+	if IsTerminalError(err) {
+		apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+			Type:    fluxmeta.ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.VerificationFailedReason,
+			Message: err.Error(),
+		})
+		apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+			Type:    fluxmeta.StalledCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.VerificationFailedReason,
+			Message: err.Error(),
+		})
+		if previousReady == nil || previousReady.Status != metav1.ConditionFalse || previousReady.Reason != v1alpha1.VerificationFailedReason {
+			r.Recorder.Event(component, "Warning", v1alpha1.VerificationFailedReason, err.Error())
+		}
+		return
 	}
 
-	return r.reconcile(ctx, component)
+	apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    fluxmeta.ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ComponentVersionFetchFailedReason,
+		Message: err.Error(),
+	})
+	apimeta.SetStatusCondition(&component.Status.Conditions, metav1.Condition{
+		Type:    fluxmeta.ReconcilingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.ComponentVersionFetchFailedReason,
+		Message: err.Error(),
+	})
+	if previousReady == nil || previousReady.Status != metav1.ConditionFalse || previousReady.Reason != v1alpha1.ComponentVersionFetchFailedReason {
+		r.Recorder.Event(component, "Warning", v1alpha1.ComponentVersionFetchFailedReason, err.Error())
+	}
+}
+
+// reconcileResult maps a reconcile outcome to the ReconcileDuration "result"
+// label.
+func reconcileResult(err error) string {
+	if err == nil {
+		return metrics.ResultSuccess
+	}
+	if IsTerminalError(err) {
+		return metrics.ResultStalled
+	}
+	return metrics.ResultError
+}
+
+// verifyFailureReason classifies a VerifyComponent error for the
+// VerifyFailuresTotal "reason" label and for deciding whether the failure is
+// worth retrying. Errors that mean verification couldn't even run yet
+// (registry unreachable, the referenced ComponentSignaturePolicy or Secret
+// doesn't exist) are ReasonFetchError and are transient. Anything else is
+// terminal: a missing signature is ReasonSignatureMissing, while a signature
+// that doesn't verify, a Spec.Verify entry without a policyRef, and a policy
+// that doesn't cover the component or its algorithm are all
+// ReasonDigestMismatch.
+func verifyFailureReason(err error) string {
+	switch {
+	case strings.Contains(err.Error(), "failed to get component version"),
+		strings.Contains(err.Error(), "failed to get component signature policy"),
+		strings.Contains(err.Error(), "failed to get secret"):
+		return metrics.ReasonFetchError
+	case strings.Contains(err.Error(), "no signature named"):
+		return metrics.ReasonSignatureMissing
+	default:
+		return metrics.ReasonDigestMismatch
+	}
 }
 
 func (r *ComponentVersionReconciler) reconcile(ctx context.Context, obj *v1alpha1.ComponentVersion) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithName("ocm-component-version-reconcile")
 
+	log.V(4).Info("found component", "component", obj)
+
+	previousDescriptor := obj.Status.ComponentDescriptor
+
+	log.Info("running verification of component")
+	verifyStart := time.Now()
+	verification, err := r.OCMClient.VerifyComponent(ctx, obj)
+	metrics.ObserveFetch(metrics.OperationVerify, verifyStart)
+	if err != nil {
+		reason := verifyFailureReason(err)
+		metrics.IncVerifyFailure(reason)
+		if reason == metrics.ReasonFetchError {
+			return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to verify component: %w", err)
+		}
+		return ctrl.Result{}, NewTerminalError(fmt.Errorf("failed to verify component: %w", err))
+	}
+
+	obj.Status.VerifiedSignatures = verification.Signatures
+
+	// VerifyComponent short-circuits with a nil error when Spec.Verify is
+	// empty, i.e. nothing was actually checked - don't claim it was.
+	if len(obj.Spec.Verify) > 0 {
+		apimeta.SetStatusCondition(&obj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.SourceVerifiedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.VerificationSucceededReason,
+			Message: "signature verification succeeded",
+		})
+	} else {
+		apimeta.RemoveStatusCondition(&obj.Status.Conditions, v1alpha1.SourceVerifiedCondition)
+	}
+
 	// get component version
+	getStart := time.Now()
 	cv, err := r.OCMClient.GetComponentVersion(ctx, obj, obj.Spec.Component, obj.Spec.Version)
+	metrics.ObserveFetch(metrics.OperationGet, getStart)
 	if err != nil {
-		return ctrl.Result{
-			RequeueAfter: obj.GetRequeueAfter(),
-		}, fmt.Errorf("failed to get component version: %w", err)
+		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to get component version: %w", err)
 	}
 
 	// convert ComponentDescriptor to v3alpha1
 	dv := &compdesc.DescriptorVersion{}
 	cd, err := dv.ConvertFrom(cv.GetDescriptor())
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to convret component descriptor: %w", err)
+		return ctrl.Result{}, NewTerminalError(fmt.Errorf("failed to convert component descriptor: %w", err))
 	}
 
-	// setup the component descriptor kubernetes resource
-	componentName, err := r.constructComponentName(cd.GetName(), cd.GetVersion(), nil)
+	// setup the component descriptor kubernetes resource, content-addressed
+	// so that identical descriptors fetched via different roots collapse
+	// onto the same object
+	spec := cd.(*compdesc.ComponentDescriptor).Spec
+	componentName, digest, err := constructComponentName(cd.GetName(), cd.GetVersion(), spec)
 	if err != nil {
-		return ctrl.Result{
-			RequeueAfter: obj.GetRequeueAfter(),
-		}, fmt.Errorf("failed to generate name: %w", err)
+		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to generate name: %w", err)
 	}
 	descriptor := &v1alpha1.ComponentDescriptor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -119,21 +425,23 @@ func (r *ComponentVersionReconciler) reconcile(ctx context.Context, obj *v1alpha
 		},
 	}
 
-	// create or update the component descriptor kubernetes resource
+	// create or update the component descriptor kubernetes resource. The
+	// digest-derived name guarantees that an existing object under this name
+	// already has the right spec, so we only need to (re-)claim ownership of
+	// it, not rewrite its content.
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, descriptor, func() error {
-		if descriptor.ObjectMeta.CreationTimestamp.IsZero() {
-			if err := controllerutil.SetOwnerReference(obj, descriptor, r.Scheme); err != nil {
-				return fmt.Errorf("failed to set owner reference: %w", err)
+		if descriptor.Spec.Version == "" {
+			descriptor.Spec = v1alpha1.ComponentDescriptorSpec{
+				ComponentVersionSpec: spec,
+				Version:              cd.GetVersion(),
 			}
 		}
-		spec := v1alpha1.ComponentDescriptorSpec{
-			ComponentVersionSpec: cd.(*compdesc.ComponentDescriptor).Spec,
-			Version:              cd.GetVersion(),
+		if err := controllerutil.SetOwnerReference(obj, descriptor, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
 		}
-		descriptor.Spec = spec
+		v1alpha1.AddReferencedBy(descriptor, obj.GetUID())
 		return nil
 	})
-
 	if err != nil {
 		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()},
 			fmt.Errorf("failed to create or update component descriptor: %w", err)
@@ -142,7 +450,8 @@ func (r *ComponentVersionReconciler) reconcile(ctx context.Context, obj *v1alpha
 	componentDescriptor := v1alpha1.Reference{
 		Name:    cd.GetName(),
 		Version: cd.GetVersion(),
-		ComponentDescriptorRef: meta.NamespacedObjectReference{
+		Digest:  digest,
+		ComponentDescriptorRef: fluxmeta.NamespacedObjectReference{
 			Name:      descriptor.GetName(),
 			Namespace: descriptor.GetNamespace(),
 		},
@@ -152,118 +461,45 @@ func (r *ComponentVersionReconciler) reconcile(ctx context.Context, obj *v1alpha
 
 	// if references.expand is false then return here
 	if !obj.Spec.References.Expand {
-		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, err
+		obj.Status.ComponentDescriptor = componentDescriptor
+		if err := r.gcDroppedReferences(ctx, obj, previousDescriptor, componentDescriptor); err != nil {
+			return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to garbage collect dropped references: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, nil
 	}
 
-	componentDescriptor.References, err = r.parseReferences(ctx, obj, cv.GetDescriptor().References)
+	rootKey, err := newComponentKey(obj.Spec.Component, obj.Spec.Version, nil)
 	if err != nil {
-		return ctrl.Result{
-			RequeueAfter: obj.GetRequeueAfter(),
-		}, fmt.Errorf("failed to get references: %w", err)
+		return ctrl.Result{}, NewTerminalError(fmt.Errorf("failed to key root component: %w", err))
 	}
-
-	// initialize the patch helper
-	patchHelper, err := patch.NewHelper(obj, r.Client)
+	expander := newReferenceExpander(r, obj)
+	componentDescriptor.References, err = expander.expand(ctx, cv.GetDescriptor().References, ancestry{rootKey: true}, 1)
 	if err != nil {
-		return ctrl.Result{
-			RequeueAfter: obj.GetRequeueAfter(),
-		}, fmt.Errorf("failed to create patch helper: %w", err)
+		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to get references: %w", err)
 	}
+	metrics.SetReferencesTotal(obj.Spec.Component, obj.Spec.Version, countReferences(componentDescriptor.References))
 
 	obj.Status.ComponentDescriptor = componentDescriptor
 
-	if err := patchHelper.Patch(ctx, obj); err != nil {
-		return ctrl.Result{
-			RequeueAfter: obj.GetRequeueAfter(),
-		}, fmt.Errorf("failed to patch resource: %w", err)
+	if err := r.gcDroppedReferences(ctx, obj, previousDescriptor, componentDescriptor); err != nil {
+		return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, fmt.Errorf("failed to garbage collect dropped references: %w", err)
 	}
 
 	log.Info("reconciliation complete")
 	return ctrl.Result{RequeueAfter: obj.GetRequeueAfter()}, nil
 }
 
-// parseReferences takes a list of references to embedded components and constructs a dependency tree out of them.
-func (r *ComponentVersionReconciler) parseReferences(ctx context.Context, parent *v1alpha1.ComponentVersion, references ocmdesc.References) ([]v1alpha1.Reference, error) {
-	log := log.FromContext(ctx)
-	result := make([]v1alpha1.Reference, 0)
-	for _, ref := range references {
-		// get component version
-		rcv, err := r.OCMClient.GetComponentVersion(ctx, parent, ref.ComponentName, ref.Version)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get component version: %w", err)
-		}
-		// convert ComponentDescriptor to v3alpha1
-		dv := &compdesc.DescriptorVersion{}
-		cd, err := dv.ConvertFrom(rcv.GetDescriptor())
-		if err != nil {
-			return nil, fmt.Errorf("failed to convret component descriptor: %w", err)
-		}
-		// setup the component descriptor kubernetes resource
-		componentName, err := r.constructComponentName(ref.ComponentName, ref.Version, ref.GetMeta().ExtraIdentity)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate name: %w", err)
-		}
-		descriptor := &v1alpha1.ComponentDescriptor{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: parent.GetNamespace(),
-				Name:      componentName,
-			},
-			Spec: v1alpha1.ComponentDescriptorSpec{
-				ComponentVersionSpec: cd.(*compdesc.ComponentDescriptor).Spec,
-				Version:              ref.Version,
-			},
-		}
-
-		if err := controllerutil.SetOwnerReference(parent, descriptor, r.Scheme); err != nil {
-			return nil, fmt.Errorf("failed to set owner reference: %w", err)
-		}
-
-		// create or update the component descriptor kubernetes resource
-		// we don't need to update it
-		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, descriptor, func() error {
-			return nil
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create/update component descriptor: %w", err)
-		}
-		log.V(4).Info(fmt.Sprintf("%s(ed) descriptor", op), "descriptor", klog.KObj(descriptor))
-
-		reference := v1alpha1.Reference{
-			Name:    ref.Name,
-			Version: ref.Version,
-			ComponentDescriptorRef: meta.NamespacedObjectReference{
-				Name:      descriptor.Name,
-				Namespace: descriptor.Namespace,
-			},
-			ExtraIdentity: ref.ExtraIdentity,
-		}
-
-		if len(rcv.GetDescriptor().References) > 0 {
-			out, err := r.parseReferences(ctx, parent, rcv.GetDescriptor().References)
-			if err != nil {
-				return nil, err
-			}
-			reference.References = out
-		}
-		result = append(result, reference)
-	}
-	return result, nil
-}
-
-// constructComponentName constructs a unique name from a component name and version.
-func (r *ComponentVersionReconciler) constructComponentName(name, version string, identity v1.Identity) (string, error) {
-	namingScheme := struct {
-		componentName string
-		version       string
-		identity      v1.Identity
-	}{
-		componentName: name,
-		version:       version,
-		identity:      identity,
-	}
-	h, err := hash.Hash(namingScheme, nil)
+// constructComponentName derives a content-addressed name and digest for a
+// ComponentDescriptor from its canonicalized spec, so that identical
+// descriptors reached through different reference paths resolve to the same
+// Kubernetes object.
+func constructComponentName(name, version string, spec compdesc.ComponentSpec) (string, string, error) {
+	b, err := json.Marshal(spec)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate hash for name, version, identity: %w", err)
+		return "", "", fmt.Errorf("failed to canonicalize component descriptor: %w", err)
 	}
-	return fmt.Sprintf("%s-%s-%d", strings.ReplaceAll(name, "/", "-"), version, h), nil
+	sum := sha256.Sum256(b)
+	digest := hex.EncodeToString(sum[:])
+	componentName := fmt.Sprintf("%s-%s-%s", strings.ReplaceAll(name, "/", "-"), version, digest[:componentDescriptorNameDigestLength])
+	return componentName, digest, nil
 }
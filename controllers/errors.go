@@ -0,0 +1,35 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import "errors"
+
+// terminalError wraps an error that cannot be fixed by retrying the same
+// reconcile, e.g. a signature mismatch or a malformed descriptor. Reconcilers
+// surface it as Stalled=True instead of requeuing.
+type terminalError struct {
+	err error
+}
+
+// NewTerminalError marks err as terminal, i.e. not worth requeuing for.
+func NewTerminalError(err error) error {
+	return &terminalError{err: err}
+}
+
+func (e *terminalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *terminalError) Unwrap() error {
+	return e.err
+}
+
+// IsTerminalError reports whether err (or any error it wraps) was marked
+// terminal via NewTerminalError.
+func IsTerminalError(err error) bool {
+	var t *terminalError
+	return errors.As(err, &t)
+}
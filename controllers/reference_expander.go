@@ -0,0 +1,277 @@
+// Copyright 2022.
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocmdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	ocmmetav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+	compdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/versions/ocm.software/v3alpha1"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/metrics"
+)
+
+const (
+	defaultMaxReferenceDepth    = 50
+	defaultMaxReferenceCount    = 1000
+	defaultReferenceConcurrency = 4
+)
+
+// componentKey uniquely identifies a component version plus extra identity
+// for the purposes of reference-tree memoization and cycle detection.
+type componentKey struct {
+	name, version, identity string
+}
+
+func newComponentKey(name, version string, identity ocmmetav1.Identity) (componentKey, error) {
+	b, err := json.Marshal(identity)
+	if err != nil {
+		return componentKey{}, fmt.Errorf("failed to hash extra identity: %w", err)
+	}
+	return componentKey{name: name, version: version, identity: string(b)}, nil
+}
+
+// ancestry is the set of componentKeys on the current path from the root
+// ComponentVersion down to the node being expanded. Unlike the memo, it is
+// forked (copied) per branch, so it only ever flags a genuine cycle - a
+// diamond where the same node is reached via two different branches is not
+// an ancestor of itself and is handled by the memo instead.
+type ancestry map[componentKey]bool
+
+func (a ancestry) with(key componentKey) ancestry {
+	next := make(ancestry, len(a)+1)
+	for k := range a {
+		next[k] = true
+	}
+	next[key] = true
+	return next
+}
+
+// expandCall tracks a single in-flight expandOne fetch/materialize for a
+// componentKey, letting concurrent callers for the same key wait on the
+// first instead of duplicating the work.
+type expandCall struct {
+	done   chan struct{}
+	result v1alpha1.Reference
+	err    error
+}
+
+// referenceExpander expands a ComponentVersion's reference tree into
+// []v1alpha1.Reference. It fetches and materializes each unique
+// (name, version, extraIdentity) node exactly once per reconcile - even if
+// it's reached through a diamond-shaped dependency graph - detects cycles,
+// and bounds the total size of the expanded graph.
+type referenceExpander struct {
+	r      *ComponentVersionReconciler
+	parent *v1alpha1.ComponentVersion
+
+	mu       sync.Mutex
+	memo     map[componentKey]v1alpha1.Reference
+	inflight map[componentKey]*expandCall
+	count    int
+}
+
+func newReferenceExpander(r *ComponentVersionReconciler, parent *v1alpha1.ComponentVersion) *referenceExpander {
+	return &referenceExpander{
+		r:        r,
+		parent:   parent,
+		memo:     make(map[componentKey]v1alpha1.Reference),
+		inflight: make(map[componentKey]*expandCall),
+	}
+}
+
+func (e *referenceExpander) maxDepth() int {
+	if e.r.MaxReferenceDepth > 0 {
+		return e.r.MaxReferenceDepth
+	}
+	return defaultMaxReferenceDepth
+}
+
+func (e *referenceExpander) maxCount() int {
+	if e.r.MaxReferenceCount > 0 {
+		return e.r.MaxReferenceCount
+	}
+	return defaultMaxReferenceCount
+}
+
+func (e *referenceExpander) concurrency() int {
+	if e.r.Concurrency > 0 {
+		return e.r.Concurrency
+	}
+	return defaultReferenceConcurrency
+}
+
+// expand fetches and materializes references, recursing into their own
+// references. Siblings are fetched concurrently (bounded by
+// ComponentVersionReconciler.Concurrency); writes to the shared memo are
+// serialized.
+func (e *referenceExpander) expand(ctx context.Context, references ocmdesc.References, ancestors ancestry, depth int) ([]v1alpha1.Reference, error) {
+	if depth > e.maxDepth() {
+		return nil, NewTerminalError(fmt.Errorf("reference tree exceeds max depth of %d", e.maxDepth()))
+	}
+
+	result := make([]v1alpha1.Reference, len(references))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(e.concurrency())
+	for i, ref := range references {
+		i, ref := i, ref
+		g.Go(func() error {
+			out, err := e.expandOne(ctx, ref, ancestors, depth)
+			if err != nil {
+				return err
+			}
+			result[i] = out
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// expandOne resolves a single reference to its v1alpha1.Reference, fetching
+// and materializing it at most once per componentKey for the lifetime of e:
+// a memo hit short-circuits immediately, and a key already being fetched by
+// another goroutine (the diamond case - two siblings sharing a dependency)
+// waits on that fetch's result instead of starting a duplicate one.
+func (e *referenceExpander) expandOne(ctx context.Context, ref ocmdesc.Reference, ancestors ancestry, depth int) (v1alpha1.Reference, error) {
+	key, err := newComponentKey(ref.ComponentName, ref.Version, ref.GetMeta().ExtraIdentity)
+	if err != nil {
+		return v1alpha1.Reference{}, err
+	}
+
+	if ancestors[key] {
+		return v1alpha1.Reference{}, NewTerminalError(
+			fmt.Errorf("cycle detected in component reference graph at %s:%s", ref.ComponentName, ref.Version))
+	}
+
+	e.mu.Lock()
+	if cached, ok := e.memo[key]; ok {
+		e.mu.Unlock()
+		return cached, nil
+	}
+	if call, ok := e.inflight[key]; ok {
+		e.mu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	e.count++
+	exceeded := e.count > e.maxCount()
+	if exceeded {
+		e.mu.Unlock()
+		return v1alpha1.Reference{}, NewTerminalError(
+			fmt.Errorf("reference tree exceeds max reference count of %d", e.maxCount()))
+	}
+	call := &expandCall{done: make(chan struct{})}
+	e.inflight[key] = call
+	e.mu.Unlock()
+
+	reference, err := e.fetchAndMaterialize(ctx, ref, ancestors, depth, key)
+
+	e.mu.Lock()
+	delete(e.inflight, key)
+	if err == nil {
+		e.memo[key] = reference
+	}
+	e.mu.Unlock()
+
+	call.result, call.err = reference, err
+	close(call.done)
+
+	return reference, err
+}
+
+// fetchAndMaterialize fetches ref's component version and creates or updates
+// its ComponentDescriptor, recursing into its own references. It must only
+// run once per componentKey; expandOne enforces that.
+func (e *referenceExpander) fetchAndMaterialize(ctx context.Context, ref ocmdesc.Reference, ancestors ancestry, depth int, key componentKey) (v1alpha1.Reference, error) {
+	log := log.FromContext(ctx)
+
+	fetchStart := time.Now()
+	rcv, err := e.r.OCMClient.GetComponentVersion(ctx, e.parent, ref.ComponentName, ref.Version)
+	metrics.ObserveFetch(metrics.OperationGet, fetchStart)
+	if err != nil {
+		return v1alpha1.Reference{}, fmt.Errorf("failed to get component version: %w", err)
+	}
+
+	dv := &compdesc.DescriptorVersion{}
+	cd, err := dv.ConvertFrom(rcv.GetDescriptor())
+	if err != nil {
+		return v1alpha1.Reference{}, NewTerminalError(fmt.Errorf("failed to convert component descriptor: %w", err))
+	}
+	refSpec := cd.(*compdesc.ComponentDescriptor).Spec
+
+	componentName, digest, err := constructComponentName(ref.ComponentName, ref.Version, refSpec)
+	if err != nil {
+		return v1alpha1.Reference{}, fmt.Errorf("failed to generate name: %w", err)
+	}
+	descriptor := &v1alpha1.ComponentDescriptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: e.parent.GetNamespace(),
+			Name:      componentName,
+		},
+	}
+
+	// CreateOrUpdate mutates shared client state, so this part must not run
+	// concurrently with itself for the same object; the client handles
+	// concurrent writes to *different* objects fine.
+	e.mu.Lock()
+	op, err := controllerutil.CreateOrUpdate(ctx, e.r.Client, descriptor, func() error {
+		if descriptor.Spec.Version == "" {
+			descriptor.Spec = v1alpha1.ComponentDescriptorSpec{
+				ComponentVersionSpec: refSpec,
+				Version:              ref.Version,
+			}
+		}
+		if err := controllerutil.SetOwnerReference(e.parent, descriptor, e.r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		v1alpha1.AddReferencedBy(descriptor, e.parent.GetUID())
+		return nil
+	})
+	e.mu.Unlock()
+	if err != nil {
+		return v1alpha1.Reference{}, fmt.Errorf("failed to create/update component descriptor: %w", err)
+	}
+	log.V(4).Info(fmt.Sprintf("%s(ed) descriptor", op), "descriptor", klog.KObj(descriptor))
+
+	reference := v1alpha1.Reference{
+		Name:    ref.Name,
+		Version: ref.Version,
+		Digest:  digest,
+		ComponentDescriptorRef: fluxmeta.NamespacedObjectReference{
+			Name:      descriptor.Name,
+			Namespace: descriptor.Namespace,
+		},
+		ExtraIdentity: ref.ExtraIdentity,
+	}
+
+	if len(rcv.GetDescriptor().References) > 0 {
+		children, err := e.expand(ctx, rcv.GetDescriptor().References, ancestors.with(key), depth+1)
+		if err != nil {
+			return v1alpha1.Reference{}, err
+		}
+		reference.References = children
+	}
+
+	return reference, nil
+}
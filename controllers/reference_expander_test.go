@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	_ "github.com/open-component-model/ocm/pkg/contexts/datacontext/config"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	ocmdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	ocmmetav1 "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc/meta/v1"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+)
+
+func newMockComponent(name string, refs ...ocmdesc.Reference) *mockComponent {
+	return &mockComponent{
+		descriptor: &ocmdesc.ComponentDescriptor{
+			ComponentSpec: ocmdesc.ComponentSpec{
+				ObjectMeta: ocmmetav1.ObjectMeta{Name: name, Version: "v0.0.1"},
+				References: refs,
+			},
+		},
+	}
+}
+
+func newReference(refName, componentName string) ocmdesc.Reference {
+	return ocmdesc.Reference{
+		ElementMeta:   ocmdesc.ElementMeta{Name: refName, Version: "v0.0.1"},
+		ComponentName: componentName,
+	}
+}
+
+func newTestReconciler(t *testing.T, cv map[string]ocm.ComponentVersionAccess) (*ComponentVersionReconciler, *mockFetcher) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	fetcher := &mockFetcher{t: t, verified: true, cv: cv}
+	return &ComponentVersionReconciler{
+		Scheme:    scheme,
+		Client:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+		OCMClient: fetcher,
+	}, fetcher
+}
+
+func TestReferenceExpanderDeduplicatesDiamond(t *testing.T) {
+	root := newMockComponent("github.com/skarlso/root", newReference("ref-a", "github.com/skarlso/a"), newReference("ref-b", "github.com/skarlso/b"))
+	a := newMockComponent("github.com/skarlso/a", newReference("ref-s", "github.com/skarlso/shared"))
+	b := newMockComponent("github.com/skarlso/b", newReference("ref-s", "github.com/skarlso/shared"))
+	shared := newMockComponent("github.com/skarlso/shared")
+
+	r, fetcher := newTestReconciler(t, map[string]ocm.ComponentVersionAccess{
+		"github.com/skarlso/root":   root,
+		"github.com/skarlso/a":      a,
+		"github.com/skarlso/b":      b,
+		"github.com/skarlso/shared": shared,
+	})
+	// Widen the race window so two siblings reaching "shared" concurrently
+	// actually overlap instead of the first returning before the second
+	// even checks the memo.
+	fetcher.delay = 20 * time.Millisecond
+
+	obj := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "default"},
+		Spec:       v1alpha1.ComponentVersionSpec{Component: "github.com/skarlso/root", Version: "v0.0.1"},
+	}
+
+	rootKey, err := newComponentKey(obj.Spec.Component, obj.Spec.Version, nil)
+	assert.NoError(t, err)
+
+	expander := newReferenceExpander(r, obj)
+	refs, err := expander.expand(context.Background(), root.descriptor.References, ancestry{rootKey: true}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, refs, 2)
+	assert.Equal(t, refs[0].References[0].ComponentDescriptorRef.Name, refs[1].References[0].ComponentDescriptorRef.Name)
+	assert.Equal(t, 1, fetcher.calls["github.com/skarlso/shared"])
+}
+
+func TestReferenceExpanderDetectsCycle(t *testing.T) {
+	a := newMockComponent("github.com/skarlso/a", newReference("ref-b", "github.com/skarlso/b"))
+	b := newMockComponent("github.com/skarlso/b", newReference("ref-a", "github.com/skarlso/a"))
+
+	r, _ := newTestReconciler(t, map[string]ocm.ComponentVersionAccess{
+		"github.com/skarlso/a": a,
+		"github.com/skarlso/b": b,
+	})
+
+	obj := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "default"},
+		Spec:       v1alpha1.ComponentVersionSpec{Component: "github.com/skarlso/a", Version: "v0.0.1"},
+	}
+	rootKey, err := newComponentKey(obj.Spec.Component, obj.Spec.Version, nil)
+	assert.NoError(t, err)
+
+	expander := newReferenceExpander(r, obj)
+	_, err = expander.expand(context.Background(), a.descriptor.References, ancestry{rootKey: true}, 1)
+	assert.Error(t, err)
+	assert.True(t, IsTerminalError(err))
+}
+
+func TestReferenceExpanderEnforcesMaxDepth(t *testing.T) {
+	a := newMockComponent("github.com/skarlso/a", newReference("ref-b", "github.com/skarlso/b"))
+	b := newMockComponent("github.com/skarlso/b", newReference("ref-c", "github.com/skarlso/c"))
+	c := newMockComponent("github.com/skarlso/c")
+
+	r, _ := newTestReconciler(t, map[string]ocm.ComponentVersionAccess{
+		"github.com/skarlso/a": a,
+		"github.com/skarlso/b": b,
+		"github.com/skarlso/c": c,
+	})
+	r.MaxReferenceDepth = 1
+
+	obj := &v1alpha1.ComponentVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "default"},
+		Spec:       v1alpha1.ComponentVersionSpec{Component: "github.com/skarlso/a", Version: "v0.0.1"},
+	}
+	rootKey, err := newComponentKey(obj.Spec.Component, obj.Spec.Version, nil)
+	assert.NoError(t, err)
+
+	expander := newReferenceExpander(r, obj)
+	_, err = expander.expand(context.Background(), a.descriptor.References, ancestry{rootKey: true}, 1)
+	assert.Error(t, err)
+	assert.True(t, IsTerminalError(err))
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics holds the Prometheus collectors ocm-controller exposes on
+// the manager's metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Reconcile outcomes used as the ReconcileDuration "result" label.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultStalled = "stalled"
+)
+
+// VerifyComponent failure reasons used as the VerifyFailuresTotal "reason"
+// label.
+const (
+	ReasonDigestMismatch   = "digest_mismatch"
+	ReasonSignatureMissing = "signature_missing"
+	ReasonFetchError       = "fetch_error"
+)
+
+// OCMClient operations used as the FetchDuration "operation" label.
+const (
+	OperationGet    = "get"
+	OperationVerify = "verify"
+)
+
+var (
+	// ReconcileDuration observes how long ComponentVersionReconciler.Reconcile
+	// took, by outcome.
+	ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ocm_component_version_reconcile_duration_seconds",
+		Help:    "Time taken to reconcile a ComponentVersion, by result.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"result"})
+
+	// FetchDuration observes how long an OCMClient registry operation took.
+	FetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ocm_component_version_fetch_duration_seconds",
+		Help:    "Time taken for an OCM registry operation, by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// ReferencesTotal reports the size of a ComponentVersion's expanded
+	// reference tree.
+	ReferencesTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ocm_component_version_references_total",
+		Help: "Number of components in a ComponentVersion's expanded reference tree.",
+	}, []string{"component", "version"})
+
+	// VerifyFailuresTotal counts component signature verification failures,
+	// by reason.
+	VerifyFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocm_component_version_verify_failures_total",
+		Help: "Count of component signature verification failures, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(ReconcileDuration, FetchDuration, ReferencesTotal, VerifyFailuresTotal)
+}
+
+// ObserveReconcile records how long a reconcile that ended with result took,
+// measured from start.
+func ObserveReconcile(result string, start time.Time) {
+	ReconcileDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+// ObserveFetch records how long an OCMClient operation took, measured from
+// start.
+func ObserveFetch(operation string, start time.Time) {
+	FetchDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// SetReferencesTotal records the number of components in component's
+// expanded reference tree.
+func SetReferencesTotal(component, version string, count int) {
+	ReferencesTotal.WithLabelValues(component, version).Set(float64(count))
+}
+
+// IncVerifyFailure increments the verify-failure counter for reason.
+func IncVerifyFailure(reason string) {
+	VerifyFailuresTotal.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveReconcileRecordsResult(t *testing.T) {
+	ObserveReconcile(ResultSuccess, time.Now())
+	assert.Equal(t, 1, testutil.CollectAndCount(ReconcileDuration))
+}
+
+func TestSetReferencesTotal(t *testing.T) {
+	SetReferencesTotal("github.com/skarlso/root", "v0.0.1", 3)
+	assert.Equal(t, float64(3), testutil.ToFloat64(ReferencesTotal.WithLabelValues("github.com/skarlso/root", "v0.0.1")))
+}
+
+func TestIncVerifyFailure(t *testing.T) {
+	IncVerifyFailure(ReasonSignatureMissing)
+	assert.Equal(t, float64(1), testutil.ToFloat64(VerifyFailuresTotal.WithLabelValues(ReasonSignatureMissing)))
+}
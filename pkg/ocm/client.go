@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocm wraps the OCM library with the fetch and signature-verification
+// operations the reconcilers need.
+package ocm
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	ocmdesc "github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+
+	// Registers the built-in verifier backends on the pkg/verify registry.
+	_ "github.com/open-component-model/ocm-controller/pkg/verify/cosign"
+	_ "github.com/open-component-model/ocm-controller/pkg/verify/notation"
+	_ "github.com/open-component-model/ocm-controller/pkg/verify/pgp"
+	_ "github.com/open-component-model/ocm-controller/pkg/verify/x509"
+)
+
+// VerificationResult is the outcome of verifying every Signature configured
+// on a ComponentVersion: which signer (and key) satisfied each one. Policy
+// holds the name of the policy of the last entry verified.
+type VerificationResult struct {
+	Signatures []v1alpha1.VerifiedSignature
+	Policy     string
+}
+
+// FetchVerifier fetches OCM component versions and verifies their
+// signatures against the policies configured on a ComponentVersion.
+type FetchVerifier interface {
+	GetComponentVersion(ctx context.Context, obj *v1alpha1.ComponentVersion, name, version string) (ocm.ComponentVersionAccess, error)
+	VerifyComponent(ctx context.Context, obj *v1alpha1.ComponentVersion) (VerificationResult, error)
+}
+
+// Client is the default FetchVerifier, backed by the OCM library and the
+// pkg/verify backend registry.
+type Client struct {
+	client.Client
+}
+
+// NewClient creates a Client that resolves Secrets and ComponentSignaturePolicies
+// through k8sClient.
+func NewClient(k8sClient client.Client) *Client {
+	return &Client{Client: k8sClient}
+}
+
+// GetComponentVersion opens obj's repository and looks up the given
+// component's version.
+func (c *Client) GetComponentVersion(ctx context.Context, obj *v1alpha1.ComponentVersion, name, version string) (ocm.ComponentVersionAccess, error) {
+	octx := ocm.DefaultContext()
+
+	repoSpec, err := octx.RepositorySpecForConfig([]byte(fmt.Sprintf(`{"type":"OCIRegistry","baseUrl":%q}`, obj.Spec.Repository.URL)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build repository spec for %q: %w", obj.Spec.Repository.URL, err)
+	}
+
+	repo, err := octx.RepositoryForSpec(repoSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository %q: %w", obj.Spec.Repository.URL, err)
+	}
+
+	cv, err := repo.LookupComponentVersion(name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up component version %s:%s: %w", name, version, err)
+	}
+
+	return cv, nil
+}
+
+// VerifyComponent checks every entry in obj.Spec.Verify against the
+// ComponentSignaturePolicy it references, dispatching to the pkg/verify
+// backend registered for each policy signer's type until one satisfies the
+// signature.
+func (c *Client) VerifyComponent(ctx context.Context, obj *v1alpha1.ComponentVersion) (VerificationResult, error) {
+	result := VerificationResult{}
+
+	if len(obj.Spec.Verify) == 0 {
+		return result, nil
+	}
+
+	cv, err := c.GetComponentVersion(ctx, obj, obj.Spec.Component, obj.Spec.Version)
+	if err != nil {
+		return result, fmt.Errorf("failed to get component version to verify: %w", err)
+	}
+
+	signatures := cv.GetDescriptor().Signatures
+
+	for _, sig := range obj.Spec.Verify {
+		if sig.PolicyRef.Name == "" {
+			return result, fmt.Errorf("signature %q has no policyRef configured", sig.Name)
+		}
+
+		policy := &v1alpha1.ComponentSignaturePolicy{}
+		key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: sig.PolicyRef.Name}
+		if err := c.Get(ctx, key, policy); err != nil {
+			return result, fmt.Errorf("failed to get component signature policy %q: %w", key, err)
+		}
+
+		if err := checkComponentMatch(policy, key, obj.Spec.Component); err != nil {
+			return result, err
+		}
+
+		data, err := signatureData(signatures, sig.Name)
+		if err != nil {
+			return result, err
+		}
+
+		if err := checkAlgorithmAllowed(policy, key, sig.Name, data); err != nil {
+			return result, err
+		}
+
+		verified, err := c.verifyAgainstPolicy(ctx, data, policy)
+		if err != nil {
+			return result, fmt.Errorf("signature %q: %w", sig.Name, err)
+		}
+
+		result.Signatures = append(result.Signatures, verified)
+		result.Policy = policy.Name
+	}
+
+	return result, nil
+}
+
+// checkComponentMatch rejects a policy that doesn't cover componentName, per
+// ComponentSignaturePolicySpec.MatchComponents.
+func checkComponentMatch(policy *v1alpha1.ComponentSignaturePolicy, key client.ObjectKey, componentName string) error {
+	if !policy.Spec.MatchesComponent(componentName) {
+		return fmt.Errorf("component signature policy %q does not apply to component %q", key, componentName)
+	}
+	return nil
+}
+
+// checkAlgorithmAllowed rejects a signature whose algorithm isn't permitted
+// by policy, per ComponentSignaturePolicySpec.SignatureAlgorithms.
+func checkAlgorithmAllowed(policy *v1alpha1.ComponentSignaturePolicy, key client.ObjectKey, signatureName string, data verify.SignatureData) error {
+	if !policy.Spec.AllowsAlgorithm(data.Algorithm) {
+		return fmt.Errorf("signature %q uses algorithm %q, which component signature policy %q does not allow", signatureName, data.Algorithm, key)
+	}
+	return nil
+}
+
+// verifyAgainstPolicy tries every signer in policy, in order, returning the
+// first one whose backend verifies data.
+func (c *Client) verifyAgainstPolicy(ctx context.Context, data verify.SignatureData, policy *v1alpha1.ComponentSignaturePolicy) (v1alpha1.VerifiedSignature, error) {
+	var lastErr error
+	for _, signer := range policy.Spec.Signers {
+		backend, err := verify.Get(signer.Type)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		keyMaterial, err := c.secretKeyMaterial(ctx, policy.GetNamespace(), signer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		verified, err := backend.Verify(ctx, data, signer, keyMaterial)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return verified, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("policy %q has no signers", policy.Name)
+	}
+	return v1alpha1.VerifiedSignature{}, fmt.Errorf("no signer in policy %q verified this signature: %w", policy.Name, lastErr)
+}
+
+// secretKeyMaterialKey is the Secret data key the key material (PGP public
+// key, cosign public key, notation trust store bundle, or certificate) is
+// expected under.
+const secretKeyMaterialKey = "key"
+
+// secretKeyMaterial fetches the key material a Signer's SecretRef points at.
+func (c *Client) secretKeyMaterial(ctx context.Context, namespace string, signer v1alpha1.Signer) ([]byte, error) {
+	if signer.SecretRef.Name == "" {
+		return nil, fmt.Errorf("signer %q has no secretRef configured", signer.Name)
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: signer.SecretRef.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %q for signer %q: %w", signer.SecretRef.Name, signer.Name, err)
+	}
+
+	data, ok := secret.Data[secretKeyMaterialKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no %q key", signer.SecretRef.Name, secretKeyMaterialKey)
+	}
+
+	return data, nil
+}
+
+// signatureData finds the ComponentDescriptor signature entry named
+// signatureName and converts it to a verify.SignatureData.
+func signatureData(signatures []ocmdesc.Signature, signatureName string) (verify.SignatureData, error) {
+	for _, sig := range signatures {
+		if sig.Name != signatureName {
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(sig.Signature.Value)
+		if err != nil {
+			return verify.SignatureData{}, fmt.Errorf("failed to decode signature %q: %w", signatureName, err)
+		}
+
+		return verify.SignatureData{
+			Name:          sig.Name,
+			HashAlgorithm: sig.Digest.HashAlgorithm,
+			Digest:        sig.Digest.Value,
+			Algorithm:     sig.Signature.Algorithm,
+			MediaType:     sig.Signature.MediaType,
+			Value:         value,
+		}, nil
+	}
+
+	return verify.SignatureData{}, fmt.Errorf("component descriptor has no signature named %q", signatureName)
+}
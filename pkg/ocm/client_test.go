@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func TestCheckComponentMatch(t *testing.T) {
+	key := client.ObjectKey{Namespace: "default", Name: "policy"}
+
+	unrestricted := &v1alpha1.ComponentSignaturePolicy{}
+	assert.NoError(t, checkComponentMatch(unrestricted, key, "github.com/skarlso/root"))
+
+	restricted := &v1alpha1.ComponentSignaturePolicy{
+		Spec: v1alpha1.ComponentSignaturePolicySpec{
+			MatchComponents: []v1alpha1.ComponentMatch{{Component: "github.com/skarlso/*"}},
+		},
+	}
+	assert.NoError(t, checkComponentMatch(restricted, key, "github.com/skarlso/root"))
+
+	err := checkComponentMatch(restricted, key, "github.com/other/root")
+	assert.Error(t, err)
+}
+
+func TestCheckAlgorithmAllowed(t *testing.T) {
+	key := client.ObjectKey{Namespace: "default", Name: "policy"}
+
+	unrestricted := &v1alpha1.ComponentSignaturePolicy{}
+	assert.NoError(t, checkAlgorithmAllowed(unrestricted, key, "main", verify.SignatureData{Algorithm: "ECDSA"}))
+
+	restricted := &v1alpha1.ComponentSignaturePolicy{
+		Spec: v1alpha1.ComponentSignaturePolicySpec{
+			SignatureAlgorithms: []string{"RSASSA-PKCS1-V1_5"},
+		},
+	}
+	assert.NoError(t, checkAlgorithmAllowed(restricted, key, "main", verify.SignatureData{Algorithm: "RSASSA-PKCS1-V1_5"}))
+
+	err := checkAlgorithmAllowed(restricted, key, "main", verify.SignatureData{Algorithm: "ECDSA"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cosign verifies component signatures produced by cosign, either
+// against a static ECDSA public key or (not yet implemented) a keyless
+// Fulcio/Rekor identity.
+package cosign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func init() {
+	backend := &Backend{}
+	verify.Register(v1alpha1.SignerTypeCosign, backend)
+	verify.Register(v1alpha1.SignerTypeCosignKeyless, backend)
+}
+
+// Backend verifies cosign signatures.
+type Backend struct{}
+
+// Verify checks data.Value as an ECDSA signature over data.Digest, produced
+// by the signer's static public key. Keyless (OIDC/Fulcio) signers are not
+// yet supported.
+func (b *Backend) Verify(ctx context.Context, data verify.SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error) {
+	if signer.Type == v1alpha1.SignerTypeCosignKeyless {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("keyless cosign signer %q: Fulcio/Rekor verification is not implemented", signer.Name)
+	}
+
+	block, _ := pem.Decode(keyMaterial)
+	if block == nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode cosign public key for signer %q: not PEM encoded", signer.Name)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to parse cosign public key for signer %q: %w", signer.Name, err)
+	}
+
+	ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("cosign public key for signer %q is not an ECDSA key", signer.Name)
+	}
+
+	digest, err := hex.DecodeString(data.Digest)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode digest for signature %q: %w", data.Name, err)
+	}
+
+	if !ecdsa.VerifyASN1(ecdsaKey, digest, data.Value) {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("cosign signature %q did not verify against signer %q", data.Name, signer.Name)
+	}
+
+	fingerprint := sha256.Sum256(block.Bytes)
+	return v1alpha1.VerifiedSignature{
+		Name:           signer.Name,
+		KeyFingerprint: hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
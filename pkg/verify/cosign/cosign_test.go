@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+// ecdsaSignature mirrors the ASN.1 structure ecdsa.VerifyASN1 expects, so
+// tests can produce a signature without depending on ecdsa.SignASN1 (Go
+// 1.15+).
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func signDigest(t *testing.T, key *ecdsa.PrivateKey, digest []byte) []byte {
+	t.Helper()
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	require.NoError(t, err)
+
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	require.NoError(t, err)
+
+	return sig
+}
+
+func pemEncodedPublicKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestBackendVerifyECDSARoundTrip(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig := signDigest(t, key, sum[:])
+
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(sum[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	verified, err := b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer", Type: v1alpha1.SignerTypeCosign}, pemEncodedPublicKey(t, key))
+	assert.NoError(t, err)
+	assert.Equal(t, "signer", verified.Name)
+	assert.NotEmpty(t, verified.KeyFingerprint)
+}
+
+func TestBackendVerifyECDSARoundTripFailsOnTamperedDigest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig := signDigest(t, key, sum[:])
+
+	tampered := sha256.Sum256([]byte("different content"))
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(tampered[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	_, err = b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer", Type: v1alpha1.SignerTypeCosign}, pemEncodedPublicKey(t, key))
+	assert.Error(t, err)
+}
+
+func TestBackendVerifyKeylessNotImplemented(t *testing.T) {
+	b := &Backend{}
+	_, err := b.Verify(context.Background(), verify.SignatureData{}, v1alpha1.Signer{Name: "signer", Type: v1alpha1.SignerTypeCosignKeyless}, nil)
+	assert.Error(t, err)
+}
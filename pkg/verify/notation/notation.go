@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notation verifies component signatures against a notation trust
+// store: a bundle of one or more PEM-encoded certificates trusted to sign
+// directly.
+package notation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func init() {
+	verify.Register(v1alpha1.SignerTypeNotation, &Backend{})
+}
+
+// Backend verifies signatures against a notation trust store.
+type Backend struct{}
+
+// Verify checks data.Value as a signature over data.Digest, produced by any
+// certificate in the signer's trust store bundle.
+func (b *Backend) Verify(ctx context.Context, data verify.SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error) {
+	digest, err := hex.DecodeString(data.Digest)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode digest for signature %q: %w", data.Name, err)
+	}
+
+	rest := keyMaterial
+	var lastErr error
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to parse trust store certificate for signer %q: %w", signer.Name, err)
+			continue
+		}
+
+		if err := verifyWithCert(cert, data.HashAlgorithm, digest, data.Value); err != nil {
+			lastErr = err
+			continue
+		}
+
+		fingerprint := sha256.Sum256(cert.Raw)
+		return v1alpha1.VerifiedSignature{
+			Name:           signer.Name,
+			KeyFingerprint: hex.EncodeToString(fingerprint[:]),
+		}, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("trust store for signer %q contains no certificates", signer.Name)
+	}
+	return v1alpha1.VerifiedSignature{}, fmt.Errorf("notation signature %q did not verify against any certificate in signer %q's trust store: %w", data.Name, signer.Name, lastErr)
+}
+
+func verifyWithCert(cert *x509.Certificate, hashAlgorithm string, digest, signature []byte) error {
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		hash, err := verify.HashForAlgorithm(hashAlgorithm)
+		if err != nil {
+			return err
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, signature) {
+			return fmt.Errorf("signature does not match")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notation
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func selfSignedRSACert(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestBackendVerifyRSARoundTrip(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(sum[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	verified, err := b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer"}, selfSignedRSACert(t, key))
+	assert.NoError(t, err)
+	assert.Equal(t, "signer", verified.Name)
+	assert.NotEmpty(t, verified.KeyFingerprint)
+}
+
+func TestBackendVerifyTrustStoreNoMatchingCertificate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(sum[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	_, err = b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer"}, selfSignedRSACert(t, other))
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pgp verifies component signatures against an armored PGP public
+// key.
+package pgp
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // no maintained replacement covers detached-signature verification yet
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func init() {
+	verify.Register(v1alpha1.SignerTypePGP, &Backend{})
+}
+
+// Backend verifies a detached PGP signature over a component's digest.
+type Backend struct{}
+
+// Verify checks data.Value as a detached PGP signature over data.Digest,
+// produced by a key in the signer's armored public keyring.
+func (b *Backend) Verify(ctx context.Context, data verify.SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyMaterial))
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to read pgp public key for signer %q: %w", signer.Name, err)
+	}
+
+	digest, err := hex.DecodeString(data.Digest)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode digest for signature %q: %w", data.Name, err)
+	}
+
+	entity, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(digest), bytes.NewReader(data.Value))
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("pgp signature %q did not verify against signer %q: %w", data.Name, signer.Name, err)
+	}
+
+	return v1alpha1.VerifiedSignature{
+		Name:           signer.Name,
+		KeyFingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+	}, nil
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // matches the backend's import, see pgp.go
+	"golang.org/x/crypto/openpgp/armor"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func newTestEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	return entity
+}
+
+func armoredPublicKey(t *testing.T, entity *openpgp.Entity) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// detachSign signs message the same way Verify expects: a detached
+// signature over the raw digest bytes, not over a re-hash of them.
+func detachSign(t *testing.T, entity *openpgp.Entity, message []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&buf, entity, bytes.NewReader(message), nil))
+
+	return buf.Bytes()
+}
+
+func TestBackendVerifyRoundTrip(t *testing.T) {
+	entity := newTestEntity(t)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig := detachSign(t, entity, sum[:])
+
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(sum[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	verified, err := b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer"}, armoredPublicKey(t, entity))
+	assert.NoError(t, err)
+	assert.Equal(t, "signer", verified.Name)
+	assert.NotEmpty(t, verified.KeyFingerprint)
+}
+
+func TestBackendVerifyRoundTripFailsOnTamperedDigest(t *testing.T) {
+	entity := newTestEntity(t)
+
+	sum := sha256.Sum256([]byte("component descriptor content"))
+	sig := detachSign(t, entity, sum[:])
+
+	tampered := sha256.Sum256([]byte("different content"))
+	data := verify.SignatureData{
+		Name:          "main",
+		HashAlgorithm: "sha256",
+		Digest:        hex.EncodeToString(tampered[:]),
+		Value:         sig,
+	}
+
+	b := &Backend{}
+	_, err := b.Verify(context.Background(), data, v1alpha1.Signer{Name: "signer"}, armoredPublicKey(t, entity))
+	assert.Error(t, err)
+}
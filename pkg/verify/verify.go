@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verify provides the pluggable signature-verification backends
+// dispatched by a ComponentSignaturePolicy's signer type, plus the registry
+// that selects among them.
+package verify
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+)
+
+// SignatureData is the digest/signature pair a Backend verifies, extracted
+// from a ComponentDescriptor's Signatures entry.
+type SignatureData struct {
+	// Name is the ComponentDescriptor signature entry this data came from.
+	Name string
+
+	// HashAlgorithm is the algorithm the Digest was computed with, e.g. "sha256".
+	HashAlgorithm string
+
+	// Digest is the hex-encoded digest that was signed.
+	Digest string
+
+	// Algorithm identifies the signature scheme, e.g. "RSASSA-PKCS1-V1_5" or
+	// "cosign".
+	Algorithm string
+
+	// MediaType is the signature's media type, as recorded on the descriptor.
+	MediaType string
+
+	// Value is the raw signature bytes.
+	Value []byte
+}
+
+// Backend verifies a SignatureData against a single Signer's key material
+// and reports which key satisfied it.
+type Backend interface {
+	Verify(ctx context.Context, data SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error)
+}
+
+var (
+	mu       sync.RWMutex
+	backends = map[v1alpha1.SignerType]Backend{}
+)
+
+// Register makes a Backend available under signerType. Backend
+// implementations call this from their package init().
+func Register(signerType v1alpha1.SignerType, backend Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	backends[signerType] = backend
+}
+
+// Get looks up the Backend registered for signerType.
+func Get(signerType v1alpha1.SignerType) (Backend, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	backend, ok := backends[signerType]
+	if !ok {
+		return nil, fmt.Errorf("no verifier backend registered for signer type %q", signerType)
+	}
+	return backend, nil
+}
+
+// HashForAlgorithm maps a SignatureData.HashAlgorithm name to the crypto.Hash
+// it was computed with, for backends that need to pass the hash identifier
+// to an API that prepends an ASN.1 DigestInfo (e.g. rsa.VerifyPKCS1v15).
+func HashForAlgorithm(name string) (crypto.Hash, error) {
+	switch strings.ToLower(name) {
+	case "sha256", "sha-256":
+		return crypto.SHA256, nil
+	case "sha384", "sha-384":
+		return crypto.SHA384, nil
+	case "sha512", "sha-512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported hash algorithm %q", name)
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+)
+
+type stubBackend struct{}
+
+func (stubBackend) Verify(ctx context.Context, data SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error) {
+	return v1alpha1.VerifiedSignature{Name: signer.Name}, nil
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	Register("stub-for-test", stubBackend{})
+
+	backend, err := Get("stub-for-test")
+	assert.NoError(t, err)
+	assert.NotNil(t, backend)
+}
+
+func TestGetUnregisteredReturnsError(t *testing.T) {
+	_, err := Get("does-not-exist")
+	assert.Error(t, err)
+}
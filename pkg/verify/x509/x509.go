@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and Open Component Model contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package x509 verifies component signatures against a PEM-encoded
+// certificate (chain), using the leaf certificate's public key.
+package x509
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/open-component-model/ocm-controller/api/v1alpha1"
+	"github.com/open-component-model/ocm-controller/pkg/verify"
+)
+
+func init() {
+	verify.Register(v1alpha1.SignerTypeX509, &Backend{})
+}
+
+// Backend verifies signatures against a directly trusted X.509 certificate.
+type Backend struct{}
+
+// Verify checks data.Value as a signature over data.Digest, produced by the
+// leaf certificate's key. It does not build or validate a chain to a root;
+// the certificate itself is the trust anchor, as configured on the signer.
+func (b *Backend) Verify(ctx context.Context, data verify.SignatureData, signer v1alpha1.Signer, keyMaterial []byte) (v1alpha1.VerifiedSignature, error) {
+	block, _ := pem.Decode(keyMaterial)
+	if block == nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode certificate for signer %q: not PEM encoded", signer.Name)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to parse certificate for signer %q: %w", signer.Name, err)
+	}
+
+	digest, err := hex.DecodeString(data.Digest)
+	if err != nil {
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("failed to decode digest for signature %q: %w", data.Name, err)
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		hash, err := verify.HashForAlgorithm(data.HashAlgorithm)
+		if err != nil {
+			return v1alpha1.VerifiedSignature{}, fmt.Errorf("x509 signature %q: %w", data.Name, err)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, data.Value); err != nil {
+			return v1alpha1.VerifiedSignature{}, fmt.Errorf("x509 signature %q did not verify against signer %q: %w", data.Name, signer.Name, err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, data.Value) {
+			return v1alpha1.VerifiedSignature{}, fmt.Errorf("x509 signature %q did not verify against signer %q", data.Name, signer.Name)
+		}
+	default:
+		return v1alpha1.VerifiedSignature{}, fmt.Errorf("certificate for signer %q has an unsupported public key type %T", signer.Name, cert.PublicKey)
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+	return v1alpha1.VerifiedSignature{
+		Name:           signer.Name,
+		KeyFingerprint: hex.EncodeToString(fingerprint[:]),
+	}, nil
+}